@@ -8,38 +8,162 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/Mikej81/originsync/xcclient"
+)
+
+// serviceWorkerCount is the number of goroutines draining the Service
+// workqueue concurrently.
+const serviceWorkerCount = 4
+
+// serviceResyncPeriod forces a periodic re-list of Services through the
+// informer, in addition to the event-driven path, so a missed or dropped
+// watch event is eventually corrected.
+const serviceResyncPeriod = 30 * time.Second
+
+// ingressWorkerCount and ingressResyncPeriod mirror their Service
+// counterparts for the Ingress workqueue controller.
+const (
+	ingressWorkerCount  = 4
+	ingressResyncPeriod = 30 * time.Second
+)
+
+// fullResyncPeriod is how often the full drift-detection resync runs against
+// the XC API, similar to how kube-proxy's Proxier periodically resyncs
+// iptables state instead of relying solely on events.
+const fullResyncPeriod = 5 * time.Minute
+
+// managedByMarker tags the Description of every origin pool OriginSync
+// creates, so the periodic resync can tell its own pools apart from
+// user-created ones and never touches the latter.
+const managedByMarker = "managed-by=originsync"
+
+// Leader election tuning, so only one replica of OriginSync ever writes to
+// the XC API at a time. These follow the same values client-go's own
+// leaderelection example uses.
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
 )
 
 var (
 	// Environment variables
 	k8s_namespace    = os.Getenv("KUBE_NAMESPACE")   // Optional, for watching a specific namespace
 	xc_namespace     = os.Getenv("XC_NAMESPACE")     // Required, the XC namespace for the API
-	xc_token         = os.Getenv("XC_TOKEN")         // Required, the token for API authentication
 	xc_sitename      = os.Getenv("XC_SITENAME")      // Required, the site name for the Origin Pool
 	xc_siteinterface = os.Getenv("XC_SITEINTERFACE") // Required, the interface for the Site; Inside / Outside
 	api_domain       = os.Getenv("API_DOMAIN")       // Required, the API domain in https://domain.com format
+
+	// apiClient handles authentication (XC_TOKEN, XC_TOKEN_FILE, mTLS, or an
+	// in-cluster ServiceAccount token) and retries for every call to the XC
+	// API; see xcclient.New.
+	apiClient *xcclient.Client
 )
 
 func main() {
-	if xc_namespace == "" || xc_token == "" || api_domain == "" || xc_sitename == "" {
-		log.Fatal("XC_NAMESPACE, XC_TOKEN, XC_SITENAME, and API_DOMAIN environment variables must be set")
+	if xc_namespace == "" || api_domain == "" || xc_sitename == "" {
+		log.Fatal("XC_NAMESPACE, XC_SITENAME, and API_DOMAIN environment variables must be set")
 	}
 
+	client, err := xcclient.New()
+	if err != nil {
+		log.Fatalf("Error initializing XC API client: %s", err.Error())
+	}
+	apiClient = client
+
 	clientset := getClientSet()
-	watchServices(clientset, k8s_namespace)
+	runWithLeaderElection(clientset)
+}
+
+// runWithLeaderElection holds a Lease before running the controllers, so
+// multiple replicas can be deployed for HA without racing to POST/PUT/DELETE
+// against the XC API. Only the holder's OnStartedLeading callback runs
+// watchIngresses/watchServices; losing the lease exits the process so
+// Kubernetes restarts it into a clean election rather than limping along
+// with stale state.
+func runWithLeaderElection(clientset *kubernetes.Clientset) {
+	leaseNamespace := os.Getenv("ORIGINSYNC_LEASE_NAMESPACE")
+	if leaseNamespace == "" {
+		leaseNamespace = k8s_namespace
+	}
+	if leaseNamespace == "" {
+		leaseNamespace = "default"
+	}
+
+	leaseName := os.Getenv("ORIGINSYNC_LEASE_NAME")
+	if leaseName == "" {
+		leaseName = "originsync-controller"
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		log.Fatalf("Error determining hostname for leader election identity: %s", err.Error())
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: leaseNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				log.Printf("%s became leader, starting controllers", identity)
+				stop := ctx.Done()
+				go watchIngresses(clientset, k8s_namespace, stop)
+				watchServices(clientset, k8s_namespace, stop)
+			},
+			OnStoppedLeading: func() {
+				log.Printf("%s lost leadership, exiting", identity)
+				os.Exit(1)
+			},
+			OnNewLeader: func(currentID string) {
+				if currentID != identity {
+					log.Printf("New leader elected: %s", currentID)
+				}
+			},
+		},
+	})
 }
 
 func getClientSet() *kubernetes.Clientset {
@@ -58,12 +182,9 @@ func getClientSet() *kubernetes.Clientset {
 	return clientset
 }
 
-func checkOriginPoolExists(service *corev1.Service) (bool, error) {
-	// Format the service name according to the specified rules
-	formattedServiceName := formatServiceName(service.Name)
-
+func checkOriginPoolExists(poolName string) (bool, error) {
 	// Construct the URL for the API call to check existence
-	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", api_domain, xc_namespace, formattedServiceName)
+	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", api_domain, xc_namespace, poolName)
 
 	// Create the request
 	req, err := http.NewRequest("GET", url, nil)
@@ -71,14 +192,9 @@ func checkOriginPoolExists(service *corev1.Service) (bool, error) {
 		return false, fmt.Errorf("error creating request: %v", err)
 	}
 
-	// Set headers
-	req.Header.Set("Authorization", fmt.Sprintf("APIToken %s", xc_token))
-
-	// Create a new HTTP client and send the request
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := apiClient.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("error sending request to API: %v", err)
+		return false, err
 	}
 	defer resp.Body.Close()
 
@@ -95,39 +211,512 @@ func checkOriginPoolExists(service *corev1.Service) (bool, error) {
 	return false, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
 }
 
-func watchServices(clientset *kubernetes.Clientset, namespace string) {
-	watchlist := cache.NewListWatchFromClient(clientset.CoreV1().RESTClient(), "services", namespace, fields.Everything())
-	_, controller := cache.NewInformer(
-		watchlist,
-		&corev1.Service{},
-		0, // Immediate resync
-		cache.ResourceEventHandlerFuncs{
-			AddFunc: func(obj interface{}) {
-				service, ok := obj.(*corev1.Service)
-				if ok && service.Spec.Type == corev1.ServiceTypeNodePort {
-					go manageOriginPool(clientset, service)
-				}
-			},
-			UpdateFunc: func(oldObj, newObj interface{}) {
-				oldService, okOld := oldObj.(*corev1.Service)
-				_ = oldService
-				newService, okNew := newObj.(*corev1.Service)
-				if okOld && okNew && newService.Spec.Type == corev1.ServiceTypeNodePort {
-					go manageOriginPool(clientset, newService)
-				}
-			},
-			DeleteFunc: func(obj interface{}) {
-				service, ok := obj.(*corev1.Service)
-				if ok && service.Spec.Type == corev1.ServiceTypeNodePort {
-					go deleteOriginPool(service)
-				}
-			},
+// watchServices runs a workqueue-backed controller over Services: the shared
+// informer only enqueues namespace/name keys, and a pool of workers pops
+// them and calls reconcileService, which idempotently GETs the current
+// Service from the lister and computes desired state. This coalesces bursts
+// of events for the same Service, retries transient errors with exponential
+// backoff instead of dropping them, and shuts down cleanly when stop is
+// closed (e.g. on losing the leader election Lease).
+func watchServices(clientset *kubernetes.Clientset, namespace string, stop <-chan struct{}) {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, serviceResyncPeriod, informers.WithNamespace(namespace))
+	serviceInformer := factory.Core().V1().Services()
+	endpointSliceInformer := factory.Discovery().V1().EndpointSlices()
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	serviceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if shouldWatchService(obj) {
+				enqueueKey(queue, obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if shouldWatchService(newObj) {
+				enqueueKey(queue, newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if shouldWatchService(obj) {
+				enqueueKey(queue, obj)
+			}
 		},
-	)
+	})
+
+	// EndpointSlices change whenever a pod backing a Service is added,
+	// removed, or rescheduled to a different node, independent of any change
+	// to the Service object itself. Enqueueing the owning Service's key here
+	// keeps origin pools in sync with pod churn rather than waiting for the
+	// next Service mutation.
+	endpointSliceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { enqueueOwningService(queue, obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) { enqueueOwningService(queue, newObj) },
+		DeleteFunc: func(obj interface{}) { enqueueOwningService(queue, obj) },
+	})
+
+	go func() {
+		<-stop
+		queue.ShutDown()
+	}()
+
+	factory.Start(stop)
+	if !cache.WaitForCacheSync(stop, serviceInformer.Informer().HasSynced, endpointSliceInformer.Informer().HasSynced) {
+		// OnStartedLeading runs detached from leaderelection's renewal loop
+		// (client-go invokes it as "go Callbacks.OnStartedLeading(ctx)"), so
+		// simply returning here would leave this replica renewing the Lease
+		// forever without ever starting a controller, and no other replica
+		// could ever take over. Exit so the process restarts into a clean
+		// election instead.
+		log.Fatal("timed out waiting for informer caches to sync")
+	}
 
-	stop := make(chan struct{})
-	go controller.Run(stop)
-	<-stop
+	var wg sync.WaitGroup
+	for i := 0; i < serviceWorkerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for processNextServiceWorkItem(clientset, serviceInformer.Lister(), queue) {
+			}
+		}()
+	}
+
+	go runPeriodicResync(clientset, serviceInformer.Lister(), fullResyncPeriod, stop)
+
+	wg.Wait()
+}
+
+// runPeriodicResync calls reconcileOriginPools on a fixed interval, catching
+// drift the event-driven path misses: pools whose origins fell out of sync,
+// and orphan pools left behind by a Service deletion missed by the
+// workqueue (e.g. during an API server outage).
+func runPeriodicResync(clientset *kubernetes.Clientset, lister corelisters.ServiceLister, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			log.Println("Running periodic origin pool resync")
+			reconcileOriginPools(clientset, lister)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// shouldWatchService reports whether a Service's events are worth enqueuing
+// at all. The default origin-resolution strategy is NodePort (see
+// originModeForService), so a Service of any other type that doesn't
+// explicitly opt into a mode via the originsync.io/mode annotation is not
+// something OriginSync would ever know how to resolve; enqueuing it would
+// only produce a "no NodePort" error on every add/update and every informer
+// resync. Unrelated Services in the watched namespace are left untouched.
+func shouldWatchService(obj interface{}) bool {
+	service, ok := obj.(*corev1.Service)
+	if !ok {
+		tombstone, tsOk := obj.(cache.DeletedFinalStateUnknown)
+		if !tsOk {
+			return true
+		}
+		service, ok = tombstone.Obj.(*corev1.Service)
+		if !ok {
+			return true
+		}
+	}
+
+	if _, ok := service.Annotations[modeAnnotation]; ok {
+		return true
+	}
+	return service.Spec.Type == corev1.ServiceTypeNodePort
+}
+
+// enqueueKey computes the namespace/name cache key for obj and adds it to
+// the workqueue. Deletes arrive as either the object itself or a
+// DeletedFinalStateUnknown tombstone; DeletionHandlingMetaNamespaceKeyFunc
+// handles both. Shared by the Service and Ingress workqueue controllers.
+func enqueueKey(queue workqueue.RateLimitingInterface, obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	queue.Add(key)
+}
+
+// enqueueOwningService resolves the Service that owns an EndpointSlice event
+// via its kubernetes.io/service-name label and enqueues that Service's key,
+// so a pod add/remove/reschedule drives the same reconcileService path as a
+// direct Service change.
+func enqueueOwningService(queue workqueue.RateLimitingInterface, obj interface{}) {
+	slice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("unexpected object type in EndpointSlice event: %T", obj))
+			return
+		}
+		slice, ok = tombstone.Obj.(*discoveryv1.EndpointSlice)
+		if !ok {
+			utilruntime.HandleError(fmt.Errorf("unexpected object type in EndpointSlice tombstone: %T", tombstone.Obj))
+			return
+		}
+	}
+
+	serviceName, ok := slice.Labels[discoveryv1.LabelServiceName]
+	if !ok || serviceName == "" {
+		return
+	}
+
+	queue.Add(fmt.Sprintf("%s/%s", slice.Namespace, serviceName))
+}
+
+// processNextServiceWorkItem pops one key off the queue and reconciles it,
+// requeueing with backoff on error. It returns false once the queue has been
+// shut down.
+func processNextServiceWorkItem(clientset *kubernetes.Clientset, lister corelisters.ServiceLister, queue workqueue.RateLimitingInterface) bool {
+	key, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+	defer queue.Done(key)
+
+	if err := reconcileService(clientset, lister, key.(string)); err != nil {
+		if queue.NumRequeues(key) < 5 {
+			log.Printf("Error syncing service %q, retrying: %v", key, err)
+			queue.AddRateLimited(key)
+			return true
+		}
+		utilruntime.HandleError(fmt.Errorf("dropping service %q out of the queue after repeated errors: %v", key, err))
+	}
+
+	queue.Forget(key)
+	return true
+}
+
+// reconcileService idempotently syncs a single Service's origin pools from
+// its current state in the lister. A NotFound error means the Service was
+// deleted; the pools we previously created for it are cleaned up from the
+// registry kept in manageOriginPool.
+func reconcileService(clientset *kubernetes.Clientset, lister corelisters.ServiceLister, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	service, err := lister.Services(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		for _, poolName := range servicePools.get(key) {
+			deleteOriginPoolByName(poolName)
+		}
+		servicePools.forget(key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	manageOriginPool(clientset, service)
+	return nil
+}
+
+// reconcileOriginPools lists every origin pool in the XC namespace and diffs
+// it against the current set of watched Services: pools owned by a Service
+// that no longer exists (or no longer has the matching port) are deleted as
+// orphans, pools whose origin servers have drifted from the Service's
+// current resolved origins are repaired, and pools whose other spec fields
+// were changed out-of-band are logged. Pools without the managedByMarker
+// were not created by OriginSync and are never touched.
+func reconcileOriginPools(clientset *kubernetes.Clientset, lister corelisters.ServiceLister) {
+	poolNames, err := listOriginPools()
+	if err != nil {
+		log.Printf("Error listing origin pools for resync: %v", err)
+		return
+	}
+
+	for _, poolName := range poolNames {
+		pool, err := getOriginPool(poolName)
+		if err != nil {
+			log.Printf("Error fetching origin pool %s during resync: %v", poolName, err)
+			continue
+		}
+
+		owner, ok := parseManagedDescription(pool.Metadata.Description)
+		if !ok || owner.kind != "service" {
+			continue
+		}
+
+		namespace, name, err := cache.SplitMetaNamespaceKey(owner.key)
+		if err != nil {
+			log.Printf("Error parsing owner %q on pool %s: %v", owner.key, poolName, err)
+			continue
+		}
+
+		service, err := lister.Services(namespace).Get(name)
+		if apierrors.IsNotFound(err) {
+			log.Printf("Deleting orphan origin pool %s: owning service %s no longer exists", poolName, owner.key)
+			deleteOriginPoolByName(poolName)
+			continue
+		}
+		if err != nil {
+			log.Printf("Error fetching owning service for pool %s: %v", poolName, err)
+			continue
+		}
+		if ignoreService(service) {
+			continue
+		}
+
+		port, ok := findServicePortByName(service, owner.port)
+		if !ok {
+			log.Printf("Deleting orphan origin pool %s: service %s no longer has port %s", poolName, owner.key, owner.port)
+			deleteOriginPoolByName(poolName)
+			continue
+		}
+
+		desiredServers, desiredPort, err := resolveOrigins(clientset, service, port)
+		if err != nil {
+			log.Printf("Error resolving desired origins for pool %s: %v", poolName, err)
+			continue
+		}
+		desired := buildOriginPool(service, port, desiredServers, desiredPort)
+
+		if pool.Spec.LoadbalancerAlgorithm != desired.Spec.LoadbalancerAlgorithm || pool.Spec.EndpointSelection != desired.Spec.EndpointSelection {
+			log.Printf("Origin pool %s spec was mutated out-of-band (loadbalancer_algorithm=%s, endpoint_selection=%s)",
+				poolName, pool.Spec.LoadbalancerAlgorithm, pool.Spec.EndpointSelection)
+		}
+
+		if !equalStringSlices(originServerIPs(pool.Spec.OriginServers), originServerIPs(desiredServers)) {
+			log.Printf("Origin pool %s origins have drifted, repairing", poolName)
+			updateOriginPool(clientset, service, port)
+		}
+	}
+}
+
+// listOriginPools returns the names of every origin pool in the configured
+// XC namespace.
+func listOriginPools() ([]string, error) {
+	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools", api_domain, xc_namespace)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	var list OriginPoolList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("error decoding origin pool list: %v", err)
+	}
+
+	names := make([]string, len(list.Items))
+	for i, item := range list.Items {
+		names[i] = item.Name
+	}
+	return names, nil
+}
+
+// getOriginPool fetches the full spec of a single origin pool.
+func getOriginPool(poolName string) (*OriginPool, error) {
+	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", api_domain, xc_namespace, poolName)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	var pool OriginPool
+	if err := json.NewDecoder(resp.Body).Decode(&pool); err != nil {
+		return nil, fmt.Errorf("error decoding origin pool %s: %v", poolName, err)
+	}
+	return &pool, nil
+}
+
+// managedOwner identifies the Kubernetes object that owns a managed origin
+// pool, parsed back out of its Description marker.
+type managedOwner struct {
+	kind string // "service" or "ingress"
+	key  string // namespace/name of the owning object
+	port string // port name/number, for kind "service"
+}
+
+// managedDescriptionForService builds the Description marker stamped on
+// pools created for a Service port, identifying both the owner and which
+// port so reconcileOriginPools can find it again.
+func managedDescriptionForService(service *corev1.Service, port corev1.ServicePort) string {
+	return fmt.Sprintf("%s;kind=service;owner=%s/%s;port=%s", managedByMarker, service.Namespace, service.Name, portDisplayName(port))
+}
+
+// parseManagedDescription parses the Description marker back into a
+// managedOwner. ok is false for pools OriginSync did not create.
+func parseManagedDescription(description string) (owner managedOwner, ok bool) {
+	parts := strings.Split(description, ";")
+	if len(parts) == 0 || parts[0] != managedByMarker {
+		return managedOwner{}, false
+	}
+
+	for _, field := range parts[1:] {
+		k, v, found := strings.Cut(field, "=")
+		if !found {
+			continue
+		}
+		switch k {
+		case "kind":
+			owner.kind = v
+		case "owner":
+			owner.key = v
+		case "port":
+			owner.port = v
+		}
+	}
+
+	return owner, owner.kind != "" && owner.key != ""
+}
+
+// findServicePortByName returns the ServicePort whose display name (name, or
+// number when unnamed) matches portName.
+func findServicePortByName(service *corev1.Service, portName string) (corev1.ServicePort, bool) {
+	for _, port := range service.Spec.Ports {
+		if portDisplayName(port) == portName {
+			return port, true
+		}
+	}
+	return corev1.ServicePort{}, false
+}
+
+// originServerIPs extracts and sorts the IPs out of an OriginServer list so
+// two lists can be compared regardless of order.
+func originServerIPs(servers []OriginServer) []string {
+	ips := make([]string, 0, len(servers))
+	for _, server := range servers {
+		switch {
+		case server.PrivateIP != nil:
+			ips = append(ips, server.PrivateIP.IP)
+		case server.PublicIP != nil:
+			ips = append(ips, server.PublicIP.IP)
+		}
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// watchIngresses runs a workqueue-backed controller over Ingresses,
+// mirroring watchServices: the shared informer only enqueues namespace/name
+// keys, and a pool of workers pops them and calls reconcileIngress, which
+// idempotently rebuilds an Ingress's origin pools from its current state in
+// the lister. Serializing reconciles per Ingress key this way (instead of
+// spawning an unbounded goroutine per event) prevents a rapid
+// update-then-delete, or delete-then-recreate, from racing a stale
+// create/update against a delete.
+func watchIngresses(clientset *kubernetes.Clientset, namespace string, stop <-chan struct{}) {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, ingressResyncPeriod, informers.WithNamespace(namespace))
+	ingressInformer := factory.Networking().V1().Ingresses()
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	ingressInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { enqueueKey(queue, obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) { enqueueKey(queue, newObj) },
+		DeleteFunc: func(obj interface{}) { enqueueKey(queue, obj) },
+	})
+
+	go func() {
+		<-stop
+		queue.ShutDown()
+	}()
+
+	factory.Start(stop)
+	if !cache.WaitForCacheSync(stop, ingressInformer.Informer().HasSynced) {
+		log.Fatal("timed out waiting for ingress informer cache to sync")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < ingressWorkerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for processNextIngressWorkItem(clientset, ingressInformer.Lister(), queue) {
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// processNextIngressWorkItem pops one key off the queue and reconciles it,
+// requeueing with backoff on error, mirroring processNextServiceWorkItem. It
+// returns false once the queue has been shut down.
+func processNextIngressWorkItem(clientset *kubernetes.Clientset, lister networkinglisters.IngressLister, queue workqueue.RateLimitingInterface) bool {
+	key, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+	defer queue.Done(key)
+
+	if err := reconcileIngress(clientset, lister, key.(string)); err != nil {
+		if queue.NumRequeues(key) < 5 {
+			log.Printf("Error syncing ingress %q, retrying: %v", key, err)
+			queue.AddRateLimited(key)
+			return true
+		}
+		utilruntime.HandleError(fmt.Errorf("dropping ingress %q out of the queue after repeated errors: %v", key, err))
+	}
+
+	queue.Forget(key)
+	return true
+}
+
+// reconcileIngress idempotently syncs a single Ingress's origin pools from
+// its current state in the lister, mirroring reconcileService. A NotFound
+// error means the Ingress was deleted; the pools previously created for it
+// are cleaned up from the registry kept in manageIngressOriginPools.
+func reconcileIngress(clientset *kubernetes.Clientset, lister networkinglisters.IngressLister, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	ingress, err := lister.Ingresses(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		for _, poolName := range ingressPools.get(key) {
+			deleteOriginPoolByName(poolName)
+		}
+		ingressPools.forget(key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	manageIngressOriginPools(clientset, ingress)
+	return nil
 }
 
 func getNodeIPsForService(clientset *kubernetes.Clientset, service *corev1.Service) ([]string, error) {
@@ -165,156 +754,315 @@ func getNodeIPsForService(clientset *kubernetes.Clientset, service *corev1.Servi
 	return nodeIPs, nil
 }
 
-func manageOriginPool(clientset *kubernetes.Clientset, service *corev1.Service) {
-	exists, err := checkOriginPoolExists(service)
-	if err != nil {
-		log.Printf("Error checking if origin pool exists: %v", err)
-		return
+// Origin resolution modes, selected per-Service via the originsync.io/mode
+// annotation. nodeport is the default and preserves the controller's
+// original behavior.
+const (
+	modeAnnotation = "originsync.io/mode"
+
+	modeNodePort     = "nodeport"
+	modeClusterIP    = "clusterip"
+	modeEndpoints    = "endpoints"
+	modeLoadBalancer = "loadbalancer"
+)
+
+// Well-known annotations letting a Service override the hard-coded defaults
+// OriginSync would otherwise apply, without redeploying the controller.
+const (
+	lbAlgorithmAnnotation       = "originsync.io/lb-algorithm"
+	endpointSelectionAnnotation = "originsync.io/endpoint-selection"
+	noTLSAnnotation             = "originsync.io/no-tls"
+	siteNameAnnotation          = "originsync.io/site-name"
+	siteNamespaceAnnotation     = "originsync.io/site-namespace"
+	portAnnotation              = "originsync.io/port"
+	poolNameAnnotation          = "originsync.io/pool-name"
+	ignoreAnnotation            = "originsync.io/ignore"
+
+	defaultLoadbalancerAlgorithm = "LB_OVERRIDE"
+	defaultEndpointSelection     = "LOCAL_PREFERRED"
+	defaultSiteNamespace         = "system"
+)
+
+// ignoreService reports whether a Service opted out of OriginSync management
+// entirely via the originsync.io/ignore annotation.
+func ignoreService(service *corev1.Service) bool {
+	ignore, ok := service.Annotations[ignoreAnnotation]
+	return ok && strings.EqualFold(ignore, "true")
+}
+
+// siteNameForService returns the XC site a Service's origins should be
+// attached to, defaulting to the controller-wide xc_sitename.
+func siteNameForService(service *corev1.Service) string {
+	if name, ok := service.Annotations[siteNameAnnotation]; ok && name != "" {
+		return name
 	}
+	return xc_sitename
+}
 
-	if exists {
-		log.Printf("Origin pool already exists, updating: %s", service.Name)
-		updateOriginPool(clientset, service) // Assume updateOriginPool is defined similarly
-	} else {
-		log.Printf("Creating new origin pool: %s", service.Name)
-		createOriginPool(clientset, service)
+// siteNamespaceForService returns the namespace the XC site lives in,
+// defaulting to "system" as the controller always has.
+func siteNamespaceForService(service *corev1.Service) string {
+	if namespace, ok := service.Annotations[siteNamespaceAnnotation]; ok && namespace != "" {
+		return namespace
 	}
+	return defaultSiteNamespace
 }
 
-func createOriginPool(clientset *kubernetes.Clientset, service *corev1.Service) {
-	formattedServiceName := formatServiceName(service.Name)
+// originModeForService returns the origin-resolution strategy requested for
+// a Service, defaulting to nodeport when unset.
+func originModeForService(service *corev1.Service) string {
+	if mode, ok := service.Annotations[modeAnnotation]; ok && mode != "" {
+		return strings.ToLower(mode)
+	}
+	return modeNodePort
+}
+
+// resolveOrigins resolves the origin servers and upstream port XC should
+// load balance to for a single Service port, based on the service's
+// originsync.io/mode annotation.
+func resolveOrigins(clientset *kubernetes.Clientset, service *corev1.Service, port corev1.ServicePort) ([]OriginServer, int32, error) {
+	switch originModeForService(service) {
+	case modeClusterIP:
+		return resolveClusterIPOrigins(service, port)
+	case modeEndpoints:
+		return resolveEndpointOrigins(clientset, service, port)
+	case modeLoadBalancer:
+		return resolveLoadBalancerOrigins(service, port)
+	case modeNodePort:
+		return resolveNodePortOrigins(clientset, service, port)
+	default:
+		return nil, 0, fmt.Errorf("unknown origin mode %q for service %s", originModeForService(service), service.Name)
+	}
+}
+
+// resolveNodePortOrigins is the original strategy: one origin per node
+// running a backing pod, reached on the Service's NodePort.
+func resolveNodePortOrigins(clientset *kubernetes.Clientset, service *corev1.Service, port corev1.ServicePort) ([]OriginServer, int32, error) {
+	nodePort := port.NodePort
+	if key, override, ok := nodePortOverride(service, port); ok {
+		parsed, err := strconv.ParseInt(override, 10, 32)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid %s annotation %q on service %s: %v", key, override, service.Name, err)
+		}
+		nodePort = int32(parsed)
+	}
+	if nodePort == 0 {
+		return nil, 0, fmt.Errorf("port %s on service %s has no NodePort", portDisplayName(port), service.Name)
+	}
 
-	// Fetch the Node IPs dynamically
 	nodeIPs, err := getNodeIPsForService(clientset, service)
 	if err != nil {
-		log.Printf("Error fetching node IPs: %v", err)
-		return
+		return nil, 0, err
 	}
 	if len(nodeIPs) == 0 {
-		log.Printf("No nodes found for service %s, skipping origin pool creation", service.Name)
-		return
-	}
-
-	// Assume each service has at least one port and the first one is the NodePort
-	var nodePort int32
-	if len(service.Spec.Ports) > 0 && service.Spec.Ports[0].NodePort != 0 {
-		nodePort = service.Spec.Ports[0].NodePort
-	} else {
-		log.Printf("No NodePort found for service %s, skipping origin pool creation", service.Name)
-		return
+		return nil, 0, fmt.Errorf("no nodes found for service %s", service.Name)
 	}
 
-	// Construct the URL for the API call
-	apiDomain := os.Getenv("API_DOMAIN")
-	xcNamespace := os.Getenv("XC_NAMESPACE")
-	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools", apiDomain, xcNamespace)
+	return buildOriginServers(nodeIPs, siteNamespaceForService(service), siteNameForService(service)), nodePort, nil
+}
 
-	// Prepare the payload with the dynamic NodePort and IPs
-	originServers := make([]OriginServer, len(nodeIPs))
-	for i, ip := range nodeIPs {
-		originServers[i] = OriginServer{
-			PrivateIP: PrivateIP{
-				IP: ip,
-				SiteLocator: SiteLocator{
-					Site: Site{
-						Namespace: "system",
-						Name:      xc_sitename,
-						Kind:      "site",
-					},
-				},
-				InsideNetwork: map[string]interface{}{},
-			},
+// nodePortOverride returns the originsync.io/port override annotation value
+// to apply for a specific Service port, and the annotation key it came from.
+// For multi-port Services the bare originsync.io/port annotation is
+// ambiguous about which port it targets, so it's only honored when the
+// Service has exactly one port; overriding one port must instead use the
+// per-port originsync.io/port-<portname> annotation, so fixing one port's
+// NodePort can't silently redirect another port's origin pool to the same
+// value.
+func nodePortOverride(service *corev1.Service, port corev1.ServicePort) (key, value string, ok bool) {
+	scopedKey := portAnnotation + "-" + portDisplayName(port)
+	if override, ok := service.Annotations[scopedKey]; ok && override != "" {
+		return scopedKey, override, true
+	}
+	if len(service.Spec.Ports) == 1 {
+		if override, ok := service.Annotations[portAnnotation]; ok && override != "" {
+			return portAnnotation, override, true
 		}
 	}
+	return "", "", false
+}
 
-	payload := OriginPool{
-		Metadata: Metadata{
-			Name:        formattedServiceName,
-			Description: "Created by OriginSync",
-			Disable:     false,
-		},
-		Spec: Spec{
-			OriginServers:         originServers,
-			NoTLS:                 map[string]interface{}{},
-			Port:                  nodePort, // Use the dynamically fetched NodePort
-			SameAsEndpointPort:    map[string]interface{}{},
-			LoadbalancerAlgorithm: "LB_OVERRIDE",
-			EndpointSelection:     "LOCAL_PREFERRED",
-		},
+// resolveClusterIPOrigins points the origin pool at the Service's ClusterIP
+// directly, for sites with in-cluster reachability.
+func resolveClusterIPOrigins(service *corev1.Service, port corev1.ServicePort) ([]OriginServer, int32, error) {
+	if service.Spec.ClusterIP == "" || service.Spec.ClusterIP == corev1.ClusterIPNone {
+		return nil, 0, fmt.Errorf("service %s has no usable ClusterIP", service.Name)
 	}
 
-	jsonData, err := json.Marshal(payload)
+	return buildOriginServers([]string{service.Spec.ClusterIP}, siteNamespaceForService(service), siteNameForService(service)), port.Port, nil
+}
+
+// resolveEndpointOrigins enumerates the pod IPs backing a Service port
+// directly from the Endpoints API, avoiding the extra node hop NodePort mode
+// requires.
+func resolveEndpointOrigins(clientset *kubernetes.Clientset, service *corev1.Service, port corev1.ServicePort) ([]OriginServer, int32, error) {
+	endpoints, err := clientset.CoreV1().Endpoints(service.Namespace).Get(context.TODO(), service.Name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("Error marshalling payload: %v", err)
-		return
+		return nil, 0, fmt.Errorf("error fetching endpoints for service %s: %v", service.Name, err)
 	}
 
-	// Create the request
-	xcToken := os.Getenv("XC_TOKEN")
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		log.Printf("Error creating request: %v", err)
-		return
+	var podIPs []string
+	var targetPort int32
+	for _, subset := range endpoints.Subsets {
+		for _, epPort := range subset.Ports {
+			if epPort.Name != port.Name {
+				continue
+			}
+			targetPort = epPort.Port
+			for _, addr := range subset.Addresses {
+				podIPs = append(podIPs, addr.IP)
+			}
+		}
 	}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("APIToken %s", xcToken))
+	if len(podIPs) == 0 || targetPort == 0 {
+		return nil, 0, fmt.Errorf("no ready endpoints found for service %s port %s", service.Name, portDisplayName(port))
+	}
 
-	// Send the request
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		log.Printf("Error sending request to API: %v", err)
-		return
+	return buildOriginServers(podIPs, siteNamespaceForService(service), siteNameForService(service)), targetPort, nil
+}
+
+// resolveLoadBalancerOrigins uses the addresses a cloud LoadBalancer has
+// assigned the Service as public origins.
+func resolveLoadBalancerOrigins(service *corev1.Service, port corev1.ServicePort) ([]OriginServer, int32, error) {
+	if len(service.Status.LoadBalancer.Ingress) == 0 {
+		return nil, 0, fmt.Errorf("service %s has no LoadBalancer ingress address yet", service.Name)
 	}
-	defer resp.Body.Close()
 
-	// Check the response status
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("Failed to create/update origin pool: %s", resp.Status)
-	} else {
-		log.Println("Successfully created/updated origin pool")
+	var originServers []OriginServer
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		address := ingress.IP
+		if address == "" {
+			address = ingress.Hostname
+		}
+		if address == "" {
+			continue
+		}
+		originServers = append(originServers, OriginServer{PublicIP: &PublicIP{IP: address}})
+	}
+	if len(originServers) == 0 {
+		return nil, 0, fmt.Errorf("service %s LoadBalancer ingress has no usable address", service.Name)
 	}
+
+	return originServers, port.Port, nil
 }
 
-func updateOriginPool(clientset *kubernetes.Clientset, service *corev1.Service) {
-	formattedServiceName := formatServiceName(service.Name)
+// poolRegistry tracks which origin pool names are currently owned by each
+// Service cache key, so a later reconcile can clean them up once the owning
+// Service is gone and no longer available from the lister.
+type poolRegistry struct {
+	mu    sync.Mutex
+	pools map[string][]string
+}
 
-	// Fetch the Node IPs dynamically
-	nodeIPs, err := getNodeIPsForService(clientset, service)
-	if err != nil {
-		log.Printf("Error fetching node IPs: %v", err)
+func newPoolRegistry() *poolRegistry {
+	return &poolRegistry{pools: map[string][]string{}}
+}
+
+func (r *poolRegistry) set(key string, poolNames []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pools[key] = poolNames
+}
+
+func (r *poolRegistry) get(key string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pools[key]
+}
+
+func (r *poolRegistry) forget(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pools, key)
+}
+
+// servicePools records the pool names created for each Service so
+// reconcileService can delete them after the owning Service disappears.
+var servicePools = newPoolRegistry()
+
+// ingressPools records the pool names created for each Ingress so
+// reconcileIngress can delete them after the owning Ingress disappears, or
+// after a rule/path is removed from a surviving Ingress.
+var ingressPools = newPoolRegistry()
+
+func manageOriginPool(clientset *kubernetes.Clientset, service *corev1.Service) {
+	if ignoreService(service) {
+		log.Printf("Service %s has %s=true, skipping", service.Name, ignoreAnnotation)
 		return
 	}
-	if len(nodeIPs) == 0 {
-		log.Printf("No nodes found for service %s, skipping origin pool creation", service.Name)
+
+	if len(service.Spec.Ports) == 0 {
+		log.Printf("Service %s declares no ports, skipping", service.Name)
 		return
 	}
 
-	// Assume each service has at least one port and the first one is the NodePort
-	var nodePort int32
-	if len(service.Spec.Ports) > 0 && service.Spec.Ports[0].NodePort != 0 {
-		nodePort = service.Spec.Ports[0].NodePort
-	} else {
-		log.Printf("No NodePort found for service %s, skipping origin pool creation", service.Name)
+	key, err := cache.MetaNamespaceKeyFunc(service)
+	if err != nil {
+		log.Printf("Error computing cache key for service %s: %v", service.Name, err)
 		return
 	}
 
-	// Construct the URL for the API call
-	apiDomain := os.Getenv("API_DOMAIN")
-	xcNamespace := os.Getenv("XC_NAMESPACE")
-	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", apiDomain, xcNamespace, formattedServiceName)
+	poolNames := make([]string, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		poolName := poolNameForPort(service, port)
+		poolNames = append(poolNames, poolName)
+
+		exists, err := checkOriginPoolExists(poolName)
+		if err != nil {
+			log.Printf("Error checking if origin pool %s exists: %v", poolName, err)
+			continue
+		}
+
+		if exists {
+			log.Printf("Origin pool already exists, updating: %s", poolName)
+			updateOriginPool(clientset, service, port)
+		} else {
+			log.Printf("Creating new origin pool: %s", poolName)
+			createOriginPool(clientset, service, port)
+		}
+	}
+
+	servicePools.set(key, poolNames)
+}
+
+// poolNameForPort formats the origin pool name for a single port of a Service,
+// e.g. "my-svc-http" for a port named "http". Services with a single, unnamed
+// port keep the bare service name for backward compatibility. The
+// originsync.io/pool-name annotation overrides the formatted service name.
+func poolNameForPort(service *corev1.Service, port corev1.ServicePort) string {
+	formattedServiceName := formatServiceName(service.Name)
+	if override, ok := service.Annotations[poolNameAnnotation]; ok && override != "" {
+		formattedServiceName = formatServiceName(override)
+	}
+	if port.Name == "" && len(service.Spec.Ports) == 1 {
+		return formattedServiceName
+	}
+	return fmt.Sprintf("%s-%s", formattedServiceName, formatServiceName(portDisplayName(port)))
+}
+
+// portDisplayName returns the port's name, falling back to its number when
+// the port is unnamed (allowed for Services with a single port).
+func portDisplayName(port corev1.ServicePort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	return fmt.Sprintf("%d", port.Port)
+}
 
-	// Prepare the payload with the dynamic NodePort and IPs
-	originServers := make([]OriginServer, len(nodeIPs))
-	for i, ip := range nodeIPs {
+// buildOriginServers converts a set of in-cluster IPs (node IPs, a ClusterIP,
+// or pod IPs) into the private-IP OriginServer list shared by create and
+// update requests, attached to the given XC site.
+func buildOriginServers(ips []string, siteNamespace, siteName string) []OriginServer {
+	originServers := make([]OriginServer, len(ips))
+	for i, ip := range ips {
 		originServers[i] = OriginServer{
-			PrivateIP: PrivateIP{
+			PrivateIP: &PrivateIP{
 				IP: ip,
 				SiteLocator: SiteLocator{
 					Site: Site{
-						Namespace: "system",
-						Name:      xc_sitename,
+						Namespace: siteNamespace,
+						Name:      siteName,
 						Kind:      "site",
 					},
 				},
@@ -322,50 +1070,106 @@ func updateOriginPool(clientset *kubernetes.Clientset, service *corev1.Service)
 			},
 		}
 	}
+	return originServers
+}
 
-	payload := OriginPool{
+// buildOriginPool assembles the OriginPool payload for a single Service
+// port, applying any originsync.io/* overrides the Service carries so
+// createOriginPool and updateOriginPool don't each re-derive it.
+func buildOriginPool(service *corev1.Service, port corev1.ServicePort, originServers []OriginServer, upstreamPort int32) OriginPool {
+	lbAlgorithm := defaultLoadbalancerAlgorithm
+	if override, ok := service.Annotations[lbAlgorithmAnnotation]; ok && override != "" {
+		lbAlgorithm = override
+	}
+
+	endpointSelection := defaultEndpointSelection
+	if override, ok := service.Annotations[endpointSelectionAnnotation]; ok && override != "" {
+		endpointSelection = override
+	}
+
+	noTLS := map[string]interface{}{}
+	if override, ok := service.Annotations[noTLSAnnotation]; ok {
+		if enabled, err := strconv.ParseBool(override); err == nil && !enabled {
+			noTLS = nil
+		} else if err != nil {
+			log.Printf("Invalid %s annotation %q on service %s, defaulting to true", noTLSAnnotation, override, service.Name)
+		}
+	}
+
+	return OriginPool{
 		Metadata: Metadata{
-			Name:        formattedServiceName,
-			Description: "Created by OriginSync",
+			Name:        poolNameForPort(service, port),
+			Description: managedDescriptionForService(service, port),
 			Disable:     false,
 		},
 		Spec: Spec{
 			OriginServers:         originServers,
-			NoTLS:                 map[string]interface{}{},
-			Port:                  nodePort, // Use the dynamically fetched NodePort
+			NoTLS:                 noTLS,
+			Port:                  upstreamPort,
+			PortName:              port.Name,
 			SameAsEndpointPort:    map[string]interface{}{},
-			LoadbalancerAlgorithm: "LB_OVERRIDE",
-			EndpointSelection:     "LOCAL_PREFERRED",
+			LoadbalancerAlgorithm: lbAlgorithm,
+			EndpointSelection:     endpointSelection,
 		},
 	}
+}
+
+func createOriginPool(clientset *kubernetes.Clientset, service *corev1.Service, port corev1.ServicePort) {
+	originServers, upstreamPort, err := resolveOrigins(clientset, service, port)
+	if err != nil {
+		log.Printf("Error resolving origins for service %s: %v", service.Name, err)
+		return
+	}
+
+	payload := buildOriginPool(service, port, originServers, upstreamPort)
+
+	apiDomain := os.Getenv("API_DOMAIN")
+	xcNamespace := os.Getenv("XC_NAMESPACE")
+	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools", apiDomain, xcNamespace)
+
+	sendOriginPoolRequest("POST", url, payload)
+}
 
+func updateOriginPool(clientset *kubernetes.Clientset, service *corev1.Service, port corev1.ServicePort) {
+	originServers, upstreamPort, err := resolveOrigins(clientset, service, port)
+	if err != nil {
+		log.Printf("Error resolving origins for service %s: %v", service.Name, err)
+		return
+	}
+
+	payload := buildOriginPool(service, port, originServers, upstreamPort)
+
+	apiDomain := os.Getenv("API_DOMAIN")
+	xcNamespace := os.Getenv("XC_NAMESPACE")
+	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", apiDomain, xcNamespace, payload.Metadata.Name)
+
+	sendOriginPoolRequest("PUT", url, payload)
+}
+
+// sendOriginPoolRequest marshals an OriginPool payload and sends it to the XC
+// API, logging the outcome. method is typically POST (create) or PUT
+// (update).
+func sendOriginPoolRequest(method, url string, payload OriginPool) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		log.Printf("Error marshalling payload: %v", err)
 		return
 	}
 
-	// Create the request
-	xcToken := os.Getenv("XC_TOKEN")
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		log.Printf("Error creating request: %v", err)
 		return
 	}
-
-	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("APIToken %s", xcToken))
 
-	// Send the request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := apiClient.Do(req)
 	if err != nil {
 		log.Printf("Error sending request to API: %v", err)
 		return
 	}
 	defer resp.Body.Close()
 
-	// Check the response status
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("Failed to create/update origin pool: %s", resp.Status)
 	} else {
@@ -373,17 +1177,17 @@ func updateOriginPool(clientset *kubernetes.Clientset, service *corev1.Service)
 	}
 }
 
-func deleteOriginPool(service *corev1.Service) {
-	// Format the service name to meet naming conventions and append to the URI
-	formattedServiceName := formatServiceName(service.Name)
+// deleteOriginPoolByName issues the DELETE call for a single origin pool by
+// name, shared by Service and Ingress cleanup paths.
+func deleteOriginPoolByName(poolName string) {
 	apiDomain := os.Getenv("API_DOMAIN")
 	xcNamespace := os.Getenv("XC_NAMESPACE")
-	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", apiDomain, xcNamespace, formattedServiceName)
+	url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", apiDomain, xcNamespace, poolName)
 
 	// Create the payload using the Delete struct
 	deletePayload := Delete{
 		FailIfReferred: false, // maybe make this optional later?
-		Name:           formattedServiceName,
+		Name:           poolName,
 		Namespace:      xcNamespace,
 	}
 
@@ -400,15 +1204,9 @@ func deleteOriginPool(service *corev1.Service) {
 		log.Printf("Error creating DELETE request: %v", err)
 		return
 	}
-
-	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	xcToken := os.Getenv("XC_TOKEN")
-	req.Header.Set("Authorization", fmt.Sprintf("APIToken %s", xcToken))
 
-	// Create a new HTTP client and send the request
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := apiClient.Do(req)
 	if err != nil {
 		log.Printf("Error sending DELETE request: %v", err)
 		return
@@ -423,6 +1221,151 @@ func deleteOriginPool(service *corev1.Service) {
 	}
 }
 
+// manageIngressOriginPools builds or updates one origin pool per backend
+// referenced by an Ingress's rules, pointed at the backend Service's
+// ClusterIP. The set of pool names created is recorded in ingressPools so
+// reconcileIngress can clean them up once the Ingress disappears.
+func manageIngressOriginPools(clientset *kubernetes.Clientset, ingress *networkingv1.Ingress) {
+	key, err := cache.MetaNamespaceKeyFunc(ingress)
+	if err != nil {
+		log.Printf("Error computing cache key for ingress %s: %v", ingress.Name, err)
+		return
+	}
+
+	var poolNames []string
+	for ruleIdx, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for pathIdx, path := range rule.HTTP.Paths {
+			backend := path.Backend.Service
+			if backend == nil {
+				continue
+			}
+
+			service, err := clientset.CoreV1().Services(ingress.Namespace).Get(context.TODO(), backend.Name, metav1.GetOptions{})
+			if err != nil {
+				log.Printf("Error fetching backend service %s for ingress %s: %v", backend.Name, ingress.Name, err)
+				continue
+			}
+
+			port, err := servicePortFor(service, backend.Port)
+			if err != nil {
+				log.Printf("Error resolving backend port for ingress %s: %v", ingress.Name, err)
+				continue
+			}
+
+			originServers, upstreamPort, err := resolveClusterIPOrigins(service, port)
+			if err != nil {
+				log.Printf("Error resolving origins for ingress %s backend %s: %v", ingress.Name, backend.Name, err)
+				continue
+			}
+
+			poolName := poolNameForIngressBackend(ingress, ruleIdx, pathIdx)
+			description := managedDescriptionForIngress(ingress, ruleIdx, pathIdx)
+			manageIngressOriginPool(poolName, description, originServers, port.Name, upstreamPort)
+			poolNames = append(poolNames, poolName)
+		}
+	}
+
+	// A rule or path removed from a surviving Ingress never generates a
+	// delete event of its own (watchIngresses only enqueues on the Ingress
+	// itself changing, and the periodic Service resync in
+	// reconcileOriginPools never looks at ingress-owned pools), so diff
+	// against what this Ingress previously owned and delete whatever is no
+	// longer in its current rule/path list.
+	for _, stale := range stalePoolNames(ingressPools.get(key), poolNames) {
+		log.Printf("Deleting origin pool %s: rule/path removed from ingress %s", stale, key)
+		deleteOriginPoolByName(stale)
+	}
+
+	ingressPools.set(key, poolNames)
+}
+
+// stalePoolNames returns the entries of previous that are no longer present
+// in current, i.e. pool names owned by a prior reconcile that the current
+// one no longer wants.
+func stalePoolNames(previous, current []string) []string {
+	keep := make(map[string]bool, len(current))
+	for _, name := range current {
+		keep[name] = true
+	}
+
+	var stale []string
+	for _, name := range previous {
+		if !keep[name] {
+			stale = append(stale, name)
+		}
+	}
+	return stale
+}
+
+// managedDescriptionForIngress builds the Description marker stamped on
+// pools created for an Ingress backend.
+func managedDescriptionForIngress(ingress *networkingv1.Ingress, ruleIdx, pathIdx int) string {
+	return fmt.Sprintf("%s;kind=ingress;owner=%s/%s;rule=%d;path=%d", managedByMarker, ingress.Namespace, ingress.Name, ruleIdx, pathIdx)
+}
+
+// manageIngressOriginPool checks whether a pool already exists for an
+// Ingress backend and creates or updates it accordingly.
+func manageIngressOriginPool(poolName, description string, originServers []OriginServer, portName string, upstreamPort int32) {
+	exists, err := checkOriginPoolExists(poolName)
+	if err != nil {
+		log.Printf("Error checking if origin pool %s exists: %v", poolName, err)
+		return
+	}
+
+	apiDomain := os.Getenv("API_DOMAIN")
+	xcNamespace := os.Getenv("XC_NAMESPACE")
+
+	payload := OriginPool{
+		Metadata: Metadata{
+			Name:        poolName,
+			Description: description,
+			Disable:     false,
+		},
+		Spec: Spec{
+			OriginServers:         originServers,
+			NoTLS:                 map[string]interface{}{},
+			Port:                  upstreamPort,
+			PortName:              portName,
+			SameAsEndpointPort:    map[string]interface{}{},
+			LoadbalancerAlgorithm: "LB_OVERRIDE",
+			EndpointSelection:     "LOCAL_PREFERRED",
+		},
+	}
+
+	if exists {
+		log.Printf("Origin pool already exists, updating: %s", poolName)
+		url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools/%s", apiDomain, xcNamespace, poolName)
+		sendOriginPoolRequest("PUT", url, payload)
+	} else {
+		log.Printf("Creating new origin pool: %s", poolName)
+		url := fmt.Sprintf("%s/api/config/namespaces/%s/origin_pools", apiDomain, xcNamespace)
+		sendOriginPoolRequest("POST", url, payload)
+	}
+}
+
+// poolNameForIngressBackend formats the origin pool name for one Ingress
+// rule/path pair, e.g. "my-ingress-0-1".
+func poolNameForIngressBackend(ingress *networkingv1.Ingress, ruleIdx, pathIdx int) string {
+	return fmt.Sprintf("%s-%d-%d", formatServiceName(ingress.Name), ruleIdx, pathIdx)
+}
+
+// servicePortFor resolves an Ingress backend's port reference (by name or
+// number) to the matching ServicePort on the backend Service.
+func servicePortFor(service *corev1.Service, backendPort networkingv1.ServiceBackendPort) (corev1.ServicePort, error) {
+	for _, port := range service.Spec.Ports {
+		if backendPort.Name != "" && port.Name == backendPort.Name {
+			return port, nil
+		}
+		if backendPort.Number != 0 && port.Port == backendPort.Number {
+			return port, nil
+		}
+	}
+	return corev1.ServicePort{}, fmt.Errorf("no matching port on service %s", service.Name)
+}
+
 func formatServiceName(serviceName string) string {
 	// Replace periods with dashes
 	formattedName := strings.ReplaceAll(serviceName, ".", "-")
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPortDisplayName(t *testing.T) {
+	cases := []struct {
+		name string
+		port corev1.ServicePort
+		want string
+	}{
+		{name: "named port", port: corev1.ServicePort{Name: "http", Port: 80}, want: "http"},
+		{name: "unnamed port falls back to number", port: corev1.ServicePort{Port: 8080}, want: "8080"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := portDisplayName(tc.port); got != tc.want {
+				t.Errorf("portDisplayName(%+v) = %q, want %q", tc.port, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPoolNameForPort(t *testing.T) {
+	cases := []struct {
+		name    string
+		service *corev1.Service
+		port    corev1.ServicePort
+		want    string
+	}{
+		{
+			name:    "single unnamed port keeps bare service name",
+			service: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-svc"}, Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}}},
+			port:    corev1.ServicePort{Port: 80},
+			want:    "my-svc",
+		},
+		{
+			name: "multiple ports are suffixed with the port name",
+			service: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-svc"}, Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80}, {Name: "grpc", Port: 9000},
+			}}},
+			port: corev1.ServicePort{Name: "http", Port: 80},
+			want: "my-svc-http",
+		},
+		{
+			name: "pool-name annotation overrides the formatted service name",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-svc", Annotations: map[string]string{poolNameAnnotation: "Custom.Pool"}},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+			},
+			port: corev1.ServicePort{Port: 80},
+			want: "custom-pool",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := poolNameForPort(tc.service, tc.port); got != tc.want {
+				t.Errorf("poolNameForPort() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestManagedDescriptionRoundTrip guards the Description marker format
+// reconcileOriginPools relies on to find the Service (and port) owning a
+// pool during the drift-detection resync; a typo here would silently break
+// orphan cleanup with nothing else to catch it.
+func TestManagedDescriptionRoundTrip(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-svc"}}
+	port := corev1.ServicePort{Name: "http", Port: 80}
+
+	description := managedDescriptionForService(service, port)
+
+	owner, ok := parseManagedDescription(description)
+	if !ok {
+		t.Fatalf("parseManagedDescription(%q) returned ok=false, want true", description)
+	}
+	if owner.kind != "service" {
+		t.Errorf("owner.kind = %q, want %q", owner.kind, "service")
+	}
+	if owner.key != "default/my-svc" {
+		t.Errorf("owner.key = %q, want %q", owner.key, "default/my-svc")
+	}
+	if owner.port != "http" {
+		t.Errorf("owner.port = %q, want %q", owner.port, "http")
+	}
+}
+
+func TestParseManagedDescriptionRejectsUnmanagedPools(t *testing.T) {
+	if _, ok := parseManagedDescription("hand-created pool, do not touch"); ok {
+		t.Error("parseManagedDescription() = ok=true for a non-OriginSync description, want false")
+	}
+}
+
+// TestStalePoolNames guards the diff manageIngressOriginPools uses to clean
+// up pools for a rule/path removed from a surviving Ingress.
+func TestStalePoolNames(t *testing.T) {
+	previous := []string{"my-ingress-0-0", "my-ingress-0-1", "my-ingress-1-0"}
+	current := []string{"my-ingress-0-0", "my-ingress-1-0"}
+
+	got := stalePoolNames(previous, current)
+	if len(got) != 1 || got[0] != "my-ingress-0-1" {
+		t.Errorf("stalePoolNames(%v, %v) = %v, want [my-ingress-0-1]", previous, current, got)
+	}
+
+	if got := stalePoolNames(nil, current); len(got) != 0 {
+		t.Errorf("stalePoolNames(nil, %v) = %v, want empty", current, got)
+	}
+}
+
+// TestNodePortOverrideScoping guards the multi-port scoping of the
+// originsync.io/port override: setting it for one port of a multi-port
+// Service must not redirect another port's origin pool to the same
+// overridden NodePort.
+func TestNodePortOverrideScoping(t *testing.T) {
+	ports := []corev1.ServicePort{
+		{Name: "http", Port: 80, NodePort: 30080},
+		{Name: "https", Port: 443, NodePort: 30443},
+	}
+
+	t.Run("bare annotation is ignored on a multi-port service", func(t *testing.T) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-svc", Annotations: map[string]string{portAnnotation: "30999"}},
+			Spec:       corev1.ServiceSpec{Ports: ports},
+		}
+
+		if _, _, ok := nodePortOverride(service, ports[0]); ok {
+			t.Error("nodePortOverride() = ok=true for the bare annotation on a multi-port service, want false")
+		}
+		if _, _, ok := nodePortOverride(service, ports[1]); ok {
+			t.Error("nodePortOverride() = ok=true for the bare annotation on a multi-port service, want false")
+		}
+	})
+
+	t.Run("per-port annotation only overrides its own port", func(t *testing.T) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-svc", Annotations: map[string]string{
+				portAnnotation + "-http": "30999",
+			}},
+			Spec: corev1.ServiceSpec{Ports: ports},
+		}
+
+		_, value, ok := nodePortOverride(service, ports[0])
+		if !ok || value != "30999" {
+			t.Errorf("nodePortOverride(http) = (%q, %v), want (30999, true)", value, ok)
+		}
+		if _, _, ok := nodePortOverride(service, ports[1]); ok {
+			t.Error("nodePortOverride(https) = ok=true, want false: the http-scoped override must not apply to https")
+		}
+	})
+
+	t.Run("bare annotation applies on a single-port service", func(t *testing.T) {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-svc", Annotations: map[string]string{portAnnotation: "30999"}},
+			Spec:       corev1.ServiceSpec{Ports: ports[:1]},
+		}
+
+		_, value, ok := nodePortOverride(service, ports[0])
+		if !ok || value != "30999" {
+			t.Errorf("nodePortOverride() = (%q, %v), want (30999, true)", value, ok)
+		}
+	})
+}
+
+// TestBuildOriginPoolAnnotationOverrides covers the originsync.io/* override
+// precedence buildOriginPool applies on top of the hard-coded defaults.
+func TestBuildOriginPoolAnnotationOverrides(t *testing.T) {
+	port := corev1.ServicePort{Name: "http", Port: 80}
+
+	t.Run("defaults apply without annotations", func(t *testing.T) {
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-svc"}}
+		pool := buildOriginPool(service, port, nil, 80)
+
+		if pool.Spec.LoadbalancerAlgorithm != defaultLoadbalancerAlgorithm {
+			t.Errorf("LoadbalancerAlgorithm = %q, want %q", pool.Spec.LoadbalancerAlgorithm, defaultLoadbalancerAlgorithm)
+		}
+		if pool.Spec.EndpointSelection != defaultEndpointSelection {
+			t.Errorf("EndpointSelection = %q, want %q", pool.Spec.EndpointSelection, defaultEndpointSelection)
+		}
+		if pool.Spec.NoTLS == nil {
+			t.Error("NoTLS = nil, want non-nil by default")
+		}
+	})
+
+	t.Run("annotations override the defaults", func(t *testing.T) {
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-svc", Annotations: map[string]string{
+			lbAlgorithmAnnotation:       "ROUND_ROBIN",
+			endpointSelectionAnnotation: "LOCAL_ONLY",
+			noTLSAnnotation:             "false",
+		}}}
+		pool := buildOriginPool(service, port, nil, 80)
+
+		if pool.Spec.LoadbalancerAlgorithm != "ROUND_ROBIN" {
+			t.Errorf("LoadbalancerAlgorithm = %q, want %q", pool.Spec.LoadbalancerAlgorithm, "ROUND_ROBIN")
+		}
+		if pool.Spec.EndpointSelection != "LOCAL_ONLY" {
+			t.Errorf("EndpointSelection = %q, want %q", pool.Spec.EndpointSelection, "LOCAL_ONLY")
+		}
+		if pool.Spec.NoTLS != nil {
+			t.Errorf("NoTLS = %v, want nil when %s=false", pool.Spec.NoTLS, noTLSAnnotation)
+		}
+	})
+
+	t.Run("invalid no-tls annotation falls back to the default", func(t *testing.T) {
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-svc", Annotations: map[string]string{
+			noTLSAnnotation: "not-a-bool",
+		}}}
+		pool := buildOriginPool(service, port, nil, 80)
+
+		if pool.Spec.NoTLS == nil {
+			t.Error("NoTLS = nil, want the default non-nil value for an unparsable override")
+		}
+	})
+}
@@ -15,13 +15,15 @@ type Spec struct {
 	OriginServers         []OriginServer         `json:"origin_servers"`
 	NoTLS                 map[string]interface{} `json:"no_tls"`
 	Port                  int32                  `json:"port"`
+	PortName              string                 `json:"port_name,omitempty"`
 	SameAsEndpointPort    map[string]interface{} `json:"same_as_endpoint_port"`
 	LoadbalancerAlgorithm string                 `json:"loadbalancer_algorithm"`
 	EndpointSelection     string                 `json:"endpoint_selection"`
 }
 
 type OriginServer struct {
-	PrivateIP PrivateIP `json:"private_ip"`
+	PrivateIP *PrivateIP `json:"private_ip,omitempty"`
+	PublicIP  *PublicIP  `json:"public_ip,omitempty"`
 }
 
 type PrivateIP struct {
@@ -31,6 +33,13 @@ type PrivateIP struct {
 	OutsideNetwork map[string]interface{} `json:"outside_network"`
 }
 
+// PublicIP identifies an origin server reachable directly over the public
+// internet, e.g. a cloud LoadBalancer address. Unlike PrivateIP it carries no
+// SiteLocator since it isn't resolved through a site's network.
+type PublicIP struct {
+	IP string `json:"ip"`
+}
+
 type SiteLocator struct {
 	Site Site `json:"site"`
 }
@@ -46,3 +55,13 @@ type Delete struct {
 	Name           string `json:"name"`
 	Namespace      string `json:"namespace"`
 }
+
+// OriginPoolList is the XC API's response to GET .../origin_pools, used by
+// the periodic resync to discover every pool in the namespace.
+type OriginPoolList struct {
+	Items []OriginPoolListItem `json:"items"`
+}
+
+type OriginPoolListItem struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,245 @@
+// Package xcclient provides a shared HTTP client for talking to the XC
+// config API: it centralizes authentication (a static or file-rotated API
+// token, mTLS client certificates, or an in-cluster ServiceAccount
+// projected token) and retry-with-backoff behavior so callers don't have to
+// re-implement either.
+package xcclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	defaultTimeout    = 10 * time.Second
+	maxRetries        = 4
+	initialRetryDelay = 500 * time.Millisecond
+	maxRetryDelay     = 30 * time.Second
+
+	// serviceAccountTokenPath is where Kubernetes projects the pod's
+	// ServiceAccount token when running in-cluster.
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// Client wraps http.Client with the XC API's authentication and retry
+// behavior. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	token     string
+	tokenFile string
+}
+
+// New builds a Client, selecting an auth mode from the environment:
+//
+//   - XC_CLIENT_CERT / XC_CLIENT_KEY (+ optional XC_CA_BUNDLE): mTLS client
+//     certificate auth, for sites that don't use API tokens.
+//   - XC_TOKEN_FILE: a path to a token file, re-read on change via fsnotify
+//     so tokens can be rotated without restarting the controller.
+//   - XC_TOKEN: a static API token.
+//   - otherwise, the in-cluster ServiceAccount projected token, watched the
+//     same way as XC_TOKEN_FILE so a token refresh is picked up.
+func New() (*Client, error) {
+	tlsConfig, err := tlsConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout:   defaultTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}
+
+	switch {
+	case tlsConfig != nil:
+		// mTLS identifies the caller; no bearer token needed.
+	case os.Getenv("XC_TOKEN_FILE") != "":
+		c.tokenFile = os.Getenv("XC_TOKEN_FILE")
+	case os.Getenv("XC_TOKEN") != "":
+		c.token = os.Getenv("XC_TOKEN")
+	default:
+		c.tokenFile = serviceAccountTokenPath
+	}
+
+	if c.tokenFile != "" {
+		if err := c.reloadTokenFile(); err != nil {
+			return nil, fmt.Errorf("error reading initial token from %s: %v", c.tokenFile, err)
+		}
+		go c.watchTokenFile()
+	}
+
+	return c, nil
+}
+
+func tlsConfigFromEnv() (*tls.Config, error) {
+	certPath := os.Getenv("XC_CLIENT_CERT")
+	keyPath := os.Getenv("XC_CLIENT_KEY")
+	if certPath == "" && keyPath == "" {
+		return nil, nil
+	}
+	if certPath == "" || keyPath == "" {
+		return nil, fmt.Errorf("XC_CLIENT_CERT and XC_CLIENT_KEY must both be set for mTLS")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading client certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if caBundlePath := os.Getenv("XC_CA_BUNDLE"); caBundlePath != "" {
+		caBundle, err := os.ReadFile(caBundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", caBundlePath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func (c *Client) reloadTokenFile() error {
+	data, err := os.ReadFile(c.tokenFile)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.token = strings.TrimSpace(string(data))
+	c.mu.Unlock()
+	return nil
+}
+
+// watchTokenFile reloads the token whenever the token file is written or
+// replaced, so a rotated token (or a refreshed ServiceAccount projection)
+// takes effect without restarting the controller.
+//
+// Both the projected ServiceAccount token and any realistic XC_TOKEN_FILE
+// live on a projected Secret/ConfigMap volume, which Kubernetes rotates by
+// atomically swapping a "..data" symlink in the mount directory rather than
+// writing the target file in place. An inotify watch on the file path itself
+// stops firing after that first swap, so watch the containing directory
+// instead and filter for events on the token file's basename or on "..data".
+func (c *Client) watchTokenFile() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("xcclient: error creating token file watcher: %v", err)
+		return
+	}
+	defer watcher.Close()
+
+	dir := filepath.Dir(c.tokenFile)
+	base := filepath.Base(c.tokenFile)
+
+	if err := watcher.Add(dir); err != nil {
+		log.Printf("xcclient: error watching token directory %s: %v", dir, err)
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if name := filepath.Base(event.Name); name != base && name != "..data" {
+				continue
+			}
+
+			if event.Op&fsnotify.Remove != 0 {
+				// The directory watch can be invalidated by the same rename
+				// that drops the old "..data" target; re-add it defensively
+				// before reloading so future swaps keep firing.
+				if err := watcher.Add(dir); err != nil {
+					log.Printf("xcclient: error re-adding token directory watch %s: %v", dir, err)
+				}
+			}
+
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+				if err := c.reloadTokenFile(); err != nil {
+					log.Printf("xcclient: error reloading token file %s: %v", c.tokenFile, err)
+				}
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("xcclient: token file watcher error: %v", err)
+		}
+	}
+}
+
+func (c *Client) currentToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// Do sends req to the XC API, attaching the configured auth and retrying on
+// 429 or 5xx responses with exponential backoff, honoring a Retry-After
+// header when the API sends one. The caller is responsible for closing the
+// returned response's body.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if token := c.currentToken(); token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("APIToken %s", token))
+	}
+
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %v", err)
+		}
+	}
+
+	delay := initialRetryDelay
+	for attempt := 0; ; attempt++ {
+		if bodyBytes != nil {
+			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error sending request to API: %v", err)
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt == maxRetries {
+			return resp, nil
+		}
+
+		wait := delay
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				wait = time.Duration(seconds) * time.Second
+			}
+		}
+		resp.Body.Close()
+
+		time.Sleep(wait)
+		if delay *= 2; delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package xcclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTLSConfigFromEnv(t *testing.T) {
+	t.Run("neither set returns no TLS config", func(t *testing.T) {
+		t.Setenv("XC_CLIENT_CERT", "")
+		t.Setenv("XC_CLIENT_KEY", "")
+
+		tlsConfig, err := tlsConfigFromEnv()
+		if err != nil {
+			t.Fatalf("tlsConfigFromEnv() error = %v, want nil", err)
+		}
+		if tlsConfig != nil {
+			t.Errorf("tlsConfigFromEnv() = %v, want nil", tlsConfig)
+		}
+	})
+
+	t.Run("only cert set is an error", func(t *testing.T) {
+		t.Setenv("XC_CLIENT_CERT", "/tmp/does-not-matter.crt")
+		t.Setenv("XC_CLIENT_KEY", "")
+
+		if _, err := tlsConfigFromEnv(); err == nil {
+			t.Error("tlsConfigFromEnv() error = nil, want an error when only XC_CLIENT_CERT is set")
+		}
+	})
+
+	t.Run("only key set is an error", func(t *testing.T) {
+		t.Setenv("XC_CLIENT_CERT", "")
+		t.Setenv("XC_CLIENT_KEY", "/tmp/does-not-matter.key")
+
+		if _, err := tlsConfigFromEnv(); err == nil {
+			t.Error("tlsConfigFromEnv() error = nil, want an error when only XC_CLIENT_KEY is set")
+		}
+	})
+
+	t.Run("both set but unreadable is an error", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XC_CLIENT_CERT", filepath.Join(dir, "missing.crt"))
+		t.Setenv("XC_CLIENT_KEY", filepath.Join(dir, "missing.key"))
+
+		if _, err := tlsConfigFromEnv(); err == nil {
+			t.Error("tlsConfigFromEnv() error = nil, want an error for a missing client certificate")
+		}
+	})
+}
+
+func TestClientDoRetriesOnServerError(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := &Client{httpClient: server.Client()}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v, want nil", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("final status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestClientDoHonorsRetryAfter(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := &Client{httpClient: server.Client()}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	start := time.Now()
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v, want nil", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("final status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	// Retry-After: 0 should skip initialRetryDelay's backoff entirely.
+	if elapsed := time.Since(start); elapsed > initialRetryDelay {
+		t.Errorf("Do() took %v, want well under initialRetryDelay (%v)", elapsed, initialRetryDelay)
+	}
+}
+
+func TestClientDoGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := &Client{httpClient: server.Client()}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v, want nil", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("final status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if want := maxRetries + 1; attempts != want {
+		t.Errorf("attempts = %d, want %d", attempts, want)
+	}
+}